@@ -0,0 +1,48 @@
+package ringbuffer
+
+// WithGrowable makes the buffer grow instead of returning ErrIsFull from Write, WriteByte, WriteString and
+// ReadFrom. max bounds how large the backing buffer may grow, or 0 for unbounded.
+func WithGrowable(max int) Option {
+	return func(b *Buffer) {
+		b.growable = true
+		b.growMax = max
+	}
+}
+
+// grow grows the backing buffer, if WithGrowable was set, to the next power of two large enough to hold
+// Length()+need bytes, capped at growMax (0 = unbounded). It linearizes the currently buffered bytes into the
+// new backing slice starting at offset 0 and resets r/w accordingly. It reports whether the buffer actually
+// grew; false means the buffer is not growable, need <= 0, or growMax already caps it at its current size.
+func (b *Buffer) grow(need int) bool {
+	if !b.growable || need <= 0 {
+		return false
+	}
+
+	cur := b.Length()
+	newSize := nextPow2(cur + need)
+	if b.growMax > 0 && newSize > b.growMax {
+		newSize = b.growMax
+	}
+	if newSize <= b.size {
+		return false
+	}
+
+	buf := make([]byte, newSize)
+	copy(buf, b.Bytes())
+	b.buf = buf
+	b.size = newSize
+	b.r = 0
+	b.w = cur
+	b.isFull = cur == newSize
+	b.invalidateUnread()
+	return true
+}
+
+// nextPow2 returns the smallest power of two that is >= n, or 1 if n <= 1.
+func nextPow2(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
@@ -0,0 +1,97 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNextPow2(t *testing.T) {
+	cases := map[int]int{0: 1, 1: 1, 2: 2, 3: 4, 4: 4, 5: 8, 8: 8, 9: 16}
+	for n, want := range cases {
+		if got := nextPow2(n); got != want {
+			t.Fatalf("nextPow2(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestWriteGrowsByDoublingAndLinearizesWrappedData(t *testing.T) {
+	// size=4, r=2, w=1: buffered bytes "cde" wrap from the end of buf around to the front.
+	b := New(WithSize(4), WithGrowable(0))
+	b.buf[2], b.buf[3], b.buf[0] = 'c', 'd', 'e'
+	b.r, b.w = 2, 1
+
+	if _, err := b.Write([]byte("fg")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.Capacity() != 8 {
+		t.Fatalf("Capacity() = %d, want 8 (next power of two >= 3+2)", b.Capacity())
+	}
+	if !b.Equal([]byte("cdefg")) {
+		t.Fatalf("buffer = %q, want %q", b.Bytes(), "cdefg")
+	}
+}
+
+func TestWriteBoundedGrowthStopsAtMaxWithErrIsFull(t *testing.T) {
+	b := New(WithSize(4), WithGrowable(8))
+	n, err := b.Write([]byte("0123456789"))
+	if err != ErrIsFull {
+		t.Fatalf("err = %v, want ErrIsFull", err)
+	}
+	if n != 8 {
+		t.Fatalf("n = %d, want 8 (grown to max, then capped)", n)
+	}
+	if b.Capacity() != 8 {
+		t.Fatalf("Capacity() = %d, want 8", b.Capacity())
+	}
+}
+
+func TestWriteUnboundedGrowthAcceptsEverything(t *testing.T) {
+	b := New(WithSize(4), WithGrowable(0))
+	data := bytes.Repeat([]byte{'z'}, 100)
+	n, err := b.Write(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != len(data) {
+		t.Fatalf("n = %d, want %d", n, len(data))
+	}
+	if b.Capacity() != 128 {
+		t.Fatalf("Capacity() = %d, want 128", b.Capacity())
+	}
+}
+
+func TestReadFromBoundedGrowthStopsAtMaxWithErrIsFull(t *testing.T) {
+	b := New(WithSize(4), WithGrowable(8))
+	n, err := b.ReadFrom(bytes.NewReader(bytes.Repeat([]byte{'x'}, 16)))
+	if err != ErrIsFull {
+		t.Fatalf("err = %v, want ErrIsFull", err)
+	}
+	if n != 8 {
+		t.Fatalf("n = %d, want 8 (grown to max, then stopped without dropping the rest silently)", n)
+	}
+	if b.Capacity() != 8 {
+		t.Fatalf("Capacity() = %d, want 8", b.Capacity())
+	}
+}
+
+func TestReadFromUnboundedGrowthReadsEverything(t *testing.T) {
+	b := New(WithSize(4), WithGrowable(0))
+	data := bytes.Repeat([]byte{'y'}, 100)
+	n, err := b.ReadFrom(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != int64(len(data)) {
+		t.Fatalf("n = %d, want %d", n, len(data))
+	}
+}
+
+func TestGrowNoopWhenNotGrowable(t *testing.T) {
+	b := New(WithSize(4))
+	if b.grow(4) {
+		t.Fatalf("grow() = true, want false: buffer is not growable")
+	}
+	if b.Capacity() != 4 {
+		t.Fatalf("Capacity() = %d, want 4", b.Capacity())
+	}
+}
@@ -0,0 +1,86 @@
+package ringbuffer
+
+import "testing"
+
+func TestPeekContiguous(t *testing.T) {
+	b := New(WithSize(8))
+	b.Write([]byte("abcd"))
+
+	head, tail, err := b.Peek(3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tail != nil {
+		t.Fatalf("tail = %q, want nil for a non-wrapped peek", tail)
+	}
+	if string(head) != "abc" {
+		t.Fatalf("head = %q, want %q", head, "abc")
+	}
+	if !b.Equal([]byte("abcd")) {
+		t.Fatalf("Peek must not advance the read pointer, buffer = %q", b.Bytes())
+	}
+}
+
+func TestPeekWrapped(t *testing.T) {
+	// size=4, r=2, w=2, isFull: buffered bytes "cdab" wrap from the end of buf around to the front.
+	b := New(WithSize(4))
+	b.buf[0], b.buf[1], b.buf[2], b.buf[3] = 'a', 'b', 'c', 'd'
+	b.r, b.w, b.isFull = 2, 2, true
+
+	head, tail, err := b.Peek(4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(head) != "cd" || string(tail) != "ab" {
+		t.Fatalf("head=%q tail=%q, want head=\"cd\" tail=\"ab\"", head, tail)
+	}
+	if b.r != 2 || b.w != 2 || !b.isFull {
+		t.Fatalf("Peek must not advance the read pointer or change isFull")
+	}
+}
+
+func TestPeekZero(t *testing.T) {
+	b := New(WithSize(4))
+	b.Write([]byte("ab"))
+	head, tail, err := b.Peek(0)
+	if err != nil || head != nil || tail != nil {
+		t.Fatalf("Peek(0) = %q, %q, %v, want nil, nil, nil", head, tail, err)
+	}
+}
+
+func TestPeekOutOfRange(t *testing.T) {
+	b := New(WithSize(4))
+	b.Write([]byte("ab"))
+	if _, _, err := b.Peek(3); err != ErrOutOfRange {
+		t.Fatalf("err = %v, want ErrOutOfRange", err)
+	}
+}
+
+func TestDiscardWrapped(t *testing.T) {
+	// size=4, r=2, w=2, isFull: buffered bytes "cdab" wrap from the end of buf around to the front.
+	b := New(WithSize(4))
+	b.buf[0], b.buf[1], b.buf[2], b.buf[3] = 'a', 'b', 'c', 'd'
+	b.r, b.w, b.isFull = 2, 2, true
+
+	n, err := b.Discard(3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 3 {
+		t.Fatalf("n = %d, want 3", n)
+	}
+	if b.isFull {
+		t.Fatalf("Discard must clear isFull once any bytes are discarded")
+	}
+	if !b.Equal([]byte("b")) {
+		t.Fatalf("buffer = %q, want %q", b.Bytes(), "b")
+	}
+}
+
+func TestDiscardOutOfRange(t *testing.T) {
+	b := New(WithSize(4))
+	b.Write([]byte("ab"))
+	if _, err := b.Discard(3); err != ErrOutOfRange {
+		t.Fatalf("err = %v, want ErrOutOfRange", err)
+	}
+}
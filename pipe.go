@@ -0,0 +1,231 @@
+package ringbuffer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// ErrClosedPipe is returned by PipeReader and PipeWriter operations after that half of the pipe has been
+// closed, and by PipeWriter writes after the reader has been closed without an explicit error. A reader whose
+// writer closed without an explicit error gets io.EOF instead.
+var ErrClosedPipe = errors.New("ringbuffer: read/write on closed pipe")
+
+// ErrTimeout is returned by a blocked PipeReader or PipeWriter call once its deadline has passed.
+var ErrTimeout = errors.New("ringbuffer: pipe i/o timeout")
+
+// pipe is the state shared by a PipeReader/PipeWriter pair returned from NewPipe. Unlike io.Pipe, which hands
+// off each Write directly to the waiting Read, a pipe buffers up to size bytes in a ring so the writer can run
+// ahead of the reader. Both sides block on cond, which is signaled on every read, write, close and deadline.
+type pipe struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	buf  *Buffer
+
+	readerDone bool
+	writerDone bool
+	rerr       error // returned by Read once the buffer drains, set by the writer's Close/CloseWithError
+	werr       error // returned by Write, set by the reader's Close/CloseWithError
+
+	readDeadline  time.Time
+	writeDeadline time.Time
+}
+
+// PipeReader is the read half of a pipe created by NewPipe.
+type PipeReader struct{ p *pipe }
+
+// PipeWriter is the write half of a pipe created by NewPipe.
+type PipeWriter struct{ p *pipe }
+
+var (
+	_ io.ReadCloser  = (*PipeReader)(nil)
+	_ io.WriteCloser = (*PipeWriter)(nil)
+)
+
+// NewPipe returns a connected in-memory pipe backed by a ring buffer of the given size. Unlike io.Pipe, which
+// is synchronous and unbuffered, Write only blocks once size bytes are in flight, letting a producer get up to
+// size bytes ahead of the consumer - the natural use case for a ring buffer between goroutines. Read blocks
+// while the pipe is empty and Write blocks while it is full; both wake through a shared sync.Cond as soon as
+// the peer makes progress, a deadline elapses, or either half is closed.
+func NewPipe(size int) (*PipeReader, *PipeWriter) {
+	p := &pipe{buf: New(WithSize(size))}
+	p.cond = sync.NewCond(&p.mu)
+	return &PipeReader{p: p}, &PipeWriter{p: p}
+}
+
+// Read implements io.Reader, blocking until data is available, the pipe is closed, or the deadline set by
+// SetReadDeadline elapses.
+func (r *PipeReader) Read(p []byte) (int, error) {
+	return r.p.read(nil, p)
+}
+
+// ReadContext behaves like Read but also returns ctx.Err() if ctx is done before data becomes available.
+func (r *PipeReader) ReadContext(ctx context.Context, p []byte) (int, error) {
+	return r.p.read(ctx, p)
+}
+
+// SetReadDeadline arms (or, given the zero Time, disarms) a deadline after which a blocked Read returns
+// ErrTimeout.
+func (r *PipeReader) SetReadDeadline(t time.Time) error {
+	return r.p.setDeadline(&r.p.readDeadline, t)
+}
+
+// Close closes the reader, equivalent to CloseWithError(nil).
+func (r *PipeReader) Close() error {
+	return r.CloseWithError(nil)
+}
+
+// CloseWithError closes the reader. Subsequent writes to the paired PipeWriter return err, or ErrClosedPipe if
+// err is nil. CloseWithError never fails and always returns nil.
+func (r *PipeReader) CloseWithError(err error) error {
+	if err == nil {
+		err = ErrClosedPipe
+	}
+	p := r.p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.readerDone = true
+	p.werr = err
+	p.cond.Broadcast()
+	return nil
+}
+
+// Write implements io.Writer, blocking until free space is available, the pipe is closed, or the deadline set
+// by SetWriteDeadline elapses.
+func (w *PipeWriter) Write(p []byte) (int, error) {
+	return w.p.write(nil, p)
+}
+
+// WriteContext behaves like Write but also returns ctx.Err() if ctx is done before space becomes available.
+func (w *PipeWriter) WriteContext(ctx context.Context, p []byte) (int, error) {
+	return w.p.write(ctx, p)
+}
+
+// SetWriteDeadline arms (or, given the zero Time, disarms) a deadline after which a blocked Write returns
+// ErrTimeout.
+func (w *PipeWriter) SetWriteDeadline(t time.Time) error {
+	return w.p.setDeadline(&w.p.writeDeadline, t)
+}
+
+// Close closes the writer, equivalent to CloseWithError(nil).
+func (w *PipeWriter) Close() error {
+	return w.CloseWithError(nil)
+}
+
+// CloseWithError closes the writer. Once the bytes already buffered are drained, subsequent reads from the
+// paired PipeReader return err, or io.EOF if err is nil. CloseWithError never fails and always returns nil.
+func (w *PipeWriter) CloseWithError(err error) error {
+	if err == nil {
+		err = io.EOF
+	}
+	p := w.p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.writerDone = true
+	p.rerr = err
+	p.cond.Broadcast()
+	return nil
+}
+
+func (p *pipe) read(ctx context.Context, out []byte) (n int, err error) {
+	if ctx != nil {
+		stop := context.AfterFunc(ctx, func() {
+			p.mu.Lock()
+			p.cond.Broadcast()
+			p.mu.Unlock()
+		})
+		defer stop()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for {
+		if p.buf.Length() > 0 {
+			n, err = p.buf.Read(out)
+			p.cond.Broadcast()
+			return n, err
+		}
+		if p.rerr != nil {
+			return 0, p.rerr
+		}
+		if p.readerDone {
+			return 0, ErrClosedPipe
+		}
+		if ctx != nil && ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+		if !p.readDeadline.IsZero() && !time.Now().Before(p.readDeadline) {
+			return 0, ErrTimeout
+		}
+		p.cond.Wait()
+	}
+}
+
+func (p *pipe) write(ctx context.Context, in []byte) (n int, err error) {
+	if ctx != nil {
+		stop := context.AfterFunc(ctx, func() {
+			p.mu.Lock()
+			p.cond.Broadcast()
+			p.mu.Unlock()
+		})
+		defer stop()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for len(in) > 0 {
+		if p.writerDone {
+			return n, ErrClosedPipe
+		}
+		if p.werr != nil {
+			return n, p.werr
+		}
+		free := p.buf.Free()
+		if free == 0 {
+			if ctx != nil && ctx.Err() != nil {
+				return n, ctx.Err()
+			}
+			if !p.writeDeadline.IsZero() && !time.Now().Before(p.writeDeadline) {
+				return n, ErrTimeout
+			}
+			p.cond.Wait()
+			continue
+		}
+		chunk := in
+		if len(chunk) > free {
+			chunk = chunk[:free]
+		}
+		wn, _ := p.buf.Write(chunk)
+		n += wn
+		in = in[wn:]
+		p.cond.Broadcast()
+	}
+	return n, nil
+}
+
+// setDeadline is shared by SetReadDeadline and SetWriteDeadline: it records t and, if t is non-zero, wakes
+// every waiter once it elapses so they can re-check their deadline.
+func (p *pipe) setDeadline(deadline *time.Time, t time.Time) error {
+	p.mu.Lock()
+	*deadline = t
+	p.mu.Unlock()
+
+	if t.IsZero() {
+		return nil
+	}
+	d := time.Until(t)
+	if d <= 0 {
+		p.mu.Lock()
+		p.cond.Broadcast()
+		p.mu.Unlock()
+		return nil
+	}
+	time.AfterFunc(d, func() {
+		p.mu.Lock()
+		p.cond.Broadcast()
+		p.mu.Unlock()
+	})
+	return nil
+}
@@ -0,0 +1,113 @@
+package ringbuffer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestPipeReadWrite(t *testing.T) {
+	r, w := NewPipe(4)
+	done := make(chan error, 1)
+	go func() {
+		_, err := w.Write([]byte("hello world"))
+		done <- err
+		w.Close()
+	}()
+
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(got) != "hello world" {
+		t.Fatalf("got %q, want %q", got, "hello world")
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+}
+
+func TestPipeWriteBlocksUntilDrained(t *testing.T) {
+	r, w := NewPipe(2)
+	wroteAll := make(chan struct{})
+	go func() {
+		w.Write([]byte("abcdef")) // bigger than the pipe's capacity
+		close(wroteAll)
+	}()
+
+	select {
+	case <-wroteAll:
+		t.Fatalf("Write returned before any bytes were drained")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	buf := make([]byte, 6)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		t.Fatalf("ReadFull: %v", err)
+	}
+	if string(buf) != "abcdef" {
+		t.Fatalf("got %q, want %q", buf, "abcdef")
+	}
+
+	select {
+	case <-wroteAll:
+	case <-time.After(time.Second):
+		t.Fatalf("Write never unblocked after the reader drained the pipe")
+	}
+}
+
+func TestPipeCloseWithError(t *testing.T) {
+	r, w := NewPipe(4)
+	boom := errors.New("boom")
+	w.CloseWithError(boom)
+
+	if _, err := r.Read(make([]byte, 1)); err != boom {
+		t.Fatalf("Read err = %v, want %v", err, boom)
+	}
+}
+
+func TestPipeReaderCloseUnblocksWriter(t *testing.T) {
+	r, w := NewPipe(2)
+	w.Write([]byte("ab")) // fill the pipe so the next write blocks
+
+	writeErr := make(chan error, 1)
+	go func() {
+		_, err := w.Write([]byte("cd"))
+		writeErr <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	r.Close()
+
+	select {
+	case err := <-writeErr:
+		if err != ErrClosedPipe {
+			t.Fatalf("Write err = %v, want %v", err, ErrClosedPipe)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("blocked Write never unblocked after the reader closed")
+	}
+}
+
+func TestPipeReadDeadline(t *testing.T) {
+	r, _ := NewPipe(4)
+	r.SetReadDeadline(time.Now().Add(20 * time.Millisecond))
+
+	_, err := r.Read(make([]byte, 1))
+	if err != ErrTimeout {
+		t.Fatalf("err = %v, want %v", err, ErrTimeout)
+	}
+}
+
+func TestPipeReadContextCancel(t *testing.T) {
+	r, _ := NewPipe(4)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := r.ReadContext(ctx, make([]byte, 1))
+	if err != context.DeadlineExceeded {
+		t.Fatalf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
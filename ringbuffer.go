@@ -15,6 +15,8 @@ var (
 	ErrAccuqireLock        = errors.New("no lock to accquire")
 	ErrOutOfRange          = errors.New("out of range")
 	ErrInvalidSliceIndices = errors.New("invalid slice indices")
+	ErrInvalidUnreadByte   = errors.New("invalid use of UnreadByte")
+	ErrInvalidUnreadRune   = errors.New("invalid use of UnreadRune")
 )
 
 const DefaultSize = 4096
@@ -26,12 +28,22 @@ type Buffer struct {
 	r      int // next position to read
 	w      int // next position to write
 	isFull bool
+
+	source io.Reader // upstream reader consulted by Read/ReadByte when the ring is empty
+	sink   io.Writer // downstream writer flushed to by Write when the ring is full
+
+	lastByte     int // last byte read by ReadByte/ReadRune, or -1 if UnreadByte is not valid
+	lastRuneSize int // size in bytes of the last rune read by ReadRune, or -1 if UnreadRune is not valid
+
+	growable bool // whether Write/WriteByte/WriteString/ReadFrom may grow buf instead of returning ErrIsFull
+	growMax  int  // largest size buf may grow to, or 0 for unbounded
 }
 
 var _ interface {
 	io.ReadWriter
-	io.ByteReader
+	io.ByteScanner
 	io.ByteWriter
+	io.RuneScanner
 	io.ReaderFrom
 	io.WriterTo
 } = (*Buffer)(nil)
@@ -42,9 +54,18 @@ func New(options ...Option) (b *Buffer) {
 	if b.buf == nil {
 		WithSize(DefaultSize)(b)
 	}
+	b.lastByte = -1
+	b.lastRuneSize = -1
 	return
 }
 
+// invalidateUnread clears the bookkeeping UnreadByte/UnreadRune rely on. It is called by every mutating
+// operation other than ReadByte and ReadRune themselves, which set it instead.
+func (b *Buffer) invalidateUnread() {
+	b.lastByte = -1
+	b.lastRuneSize = -1
+}
+
 type Option func(*Buffer)
 
 func WithBuffer(buf []byte) Option {
@@ -69,6 +90,11 @@ func WithSize(size int) Option {
 // Callers should always process the n > 0 bytes returned before considering the error err. Doing so correctly handles
 // I/O errors that happen after reading some bytes and also both of the allowed EOF behaviors.
 func (b *Buffer) Read(p []byte) (n int, err error) {
+	if b.source != nil && b.IsEmpty() {
+		if _, err = b.Fill(); err != nil {
+			return 0, err
+		}
+	}
 	n, _, err = b.ReadUntilFunc(p, nil)
 	return
 }
@@ -113,6 +139,7 @@ func (b *Buffer) ReadUntilFunc(p []byte, f func(byte) bool) (n int, atDelim bool
 		}
 		b.r = (b.r + n) % b.size
 		b.isFull = false
+		b.invalidateUnread()
 	}
 	return
 }
@@ -120,7 +147,15 @@ func (b *Buffer) ReadUntilFunc(p []byte, f func(byte) bool) (n int, atDelim bool
 // ReadByte reads and returns the next byte from the input or ErrIsEmpty.
 func (b *Buffer) ReadByte() (c byte, err error) {
 	if b.w == b.r && !b.isFull {
-		return 0, ErrIsEmpty
+		if b.source == nil {
+			return 0, ErrIsEmpty
+		}
+		if _, err = b.Fill(); err != nil {
+			return 0, err
+		}
+		if b.w == b.r && !b.isFull {
+			return 0, ErrIsEmpty
+		}
 	}
 	c = b.buf[b.r]
 	b.r++
@@ -128,6 +163,8 @@ func (b *Buffer) ReadByte() (c byte, err error) {
 		b.r = 0
 	}
 	b.isFull = false
+	b.lastByte = int(c)
+	b.lastRuneSize = -1
 	return c, err
 }
 
@@ -138,6 +175,16 @@ func (b *Buffer) Write(p []byte) (n int, err error) {
 	if len(p) == 0 {
 		return
 	}
+	if b.isFull && b.sink != nil {
+		if _, err = b.flush(); err != nil {
+			return 0, err
+		}
+	}
+	if b.growable {
+		if free := b.Free(); len(p) > free {
+			b.grow(len(p))
+		}
+	}
 	if b.isFull {
 		err = ErrIsFull
 		return
@@ -171,11 +218,15 @@ func (b *Buffer) Write(p []byte) (n int, err error) {
 	if b.w == b.r {
 		b.isFull = true
 	}
+	b.invalidateUnread()
 	return
 }
 
 // WriteByte writes one byte into buffer, and returns ErrIsFull if buffer is full.
 func (b *Buffer) WriteByte(c byte) error {
+	if b.growable && b.Free() == 0 {
+		b.grow(1)
+	}
 	err := b.writeByte(c)
 	return err
 }
@@ -193,14 +244,18 @@ func (b *Buffer) writeByte(c byte) error {
 	if b.w == b.r {
 		b.isFull = true
 	}
+	b.invalidateUnread()
 	return nil
 }
 
 func (b *Buffer) ReadFrom(r io.Reader) (n int64, err error) {
 	var c, i int
 	if b.isFull {
-		return 0, ErrIsFull
+		if !b.growable || !b.grow(b.size) {
+			return 0, ErrIsFull
+		}
 	}
+	b.invalidateUnread()
 
 	for {
 		if b.w >= b.r {
@@ -219,6 +274,10 @@ func (b *Buffer) ReadFrom(r io.Reader) (n int64, err error) {
 		}
 		if i > 0 && b.w == b.r {
 			b.isFull = true
+			if b.growable && b.grow(b.size) {
+				continue
+			}
+			err = ErrIsFull
 			break
 		}
 	}
@@ -231,6 +290,7 @@ func (b *Buffer) WriteTo(w io.Writer) (n int64, err error) {
 	if b.w == b.r && !b.isFull {
 		return
 	}
+	b.invalidateUnread()
 
 	for {
 		if b.w > b.r {
@@ -392,6 +452,7 @@ func (b *Buffer) Reset() {
 	b.r = 0
 	b.w = 0
 	b.isFull = false
+	b.invalidateUnread()
 }
 
 func (b *Buffer) Equal(p []byte) bool {
@@ -530,4 +591,38 @@ func (b *Buffer) Consume(n int) {
 	if b.isFull {
 		b.isFull = false
 	}
+	b.invalidateUnread()
+}
+
+// Peek returns the next n bytes without advancing the read pointer, aliasing the underlying buf instead of
+// copying it. If the requested range wraps around the end of buf, head holds the bytes up to the end of buf and
+// tail holds the remainder; otherwise tail is nil. The returned slices are only valid until the next call that
+// mutates the buffer (Read, Write, Consume, Discard, Reset, ...). It returns ErrOutOfRange if n > Length().
+func (b *Buffer) Peek(n int) (head []byte, tail []byte, err error) {
+	if n > b.Length() {
+		return nil, nil, ErrOutOfRange
+	}
+	if n == 0 {
+		return nil, nil, nil
+	}
+	if b.r+n <= b.size {
+		return b.buf[b.r : b.r+n], nil, nil
+	}
+	c1 := b.size - b.r
+	return b.buf[b.r:b.size], b.buf[:n-c1], nil
+}
+
+// Discard skips the next n bytes, advancing the read pointer without copying them. It returns the number of
+// bytes discarded and ErrOutOfRange if n > Length().
+func (b *Buffer) Discard(n int) (int, error) {
+	if n > b.Length() {
+		return 0, ErrOutOfRange
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	b.r = (b.r + n) % b.size
+	b.isFull = false
+	b.invalidateUnread()
+	return n, nil
 }
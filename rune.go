@@ -0,0 +1,107 @@
+package ringbuffer
+
+import "unicode/utf8"
+
+// ReadRune reads a single UTF-8 encoded Unicode character and returns the rune and its size in bytes. It
+// returns ErrIsEmpty if the buffer has nothing left to read. If a source is attached, ReadRune pulls from it
+// via Fill, both to fill an empty buffer and, for a multi-byte sequence, to top up to utf8.UTFMax bytes before
+// deciding the sequence is invalid, matching bufio.Reader.ReadRune. If the bytes at the read pointer still do
+// not form a valid encoding once no more input is forthcoming, it consumes a single byte and returns
+// (utf8.RuneError, 1, nil).
+func (b *Buffer) ReadRune() (r rune, size int, err error) {
+	bLen := b.Length()
+	if bLen == 0 && b.source != nil {
+		if _, err = b.Fill(); err != nil {
+			return 0, 0, err
+		}
+		bLen = b.Length()
+	}
+	if bLen == 0 {
+		return 0, 0, ErrIsEmpty
+	}
+
+	c := b.buf[b.r]
+	if c < utf8.RuneSelf {
+		b.r = (b.r + 1) % b.size
+		b.isFull = false
+		b.lastByte = int(c)
+		b.lastRuneSize = 1
+		return rune(c), 1, nil
+	}
+
+	// A multi-byte sequence may need more bytes than are currently buffered. Pull from an attached source
+	// until utf8.UTFMax bytes are available or the source stops supplying more, mirroring
+	// bufio.Reader.ReadRune, before deciding the sequence is invalid.
+	for bLen < utf8.UTFMax && b.source != nil {
+		filled, ferr := b.Fill()
+		bLen = b.Length()
+		if filled == 0 || ferr != nil {
+			break
+		}
+	}
+
+	// The candidate rune may straddle the wrap boundary, so assemble up to utf8.UTFMax bytes into a small
+	// stack array before decoding rather than requiring the bytes to be contiguous in buf.
+	var tmp [utf8.UTFMax]byte
+	n := bLen
+	if n > utf8.UTFMax {
+		n = utf8.UTFMax
+	}
+	for i := 0; i < n; i++ {
+		tmp[i] = b.buf[(b.r+i)%b.size]
+	}
+	r, size = utf8.DecodeRune(tmp[:n])
+
+	b.r = (b.r + size) % b.size
+	b.isFull = false
+	b.lastByte = int(tmp[size-1])
+	b.lastRuneSize = size
+	return r, size, nil
+}
+
+// WriteRune writes the UTF-8 encoding of r to the buffer and returns the number of bytes written.
+func (b *Buffer) WriteRune(r rune) (int, error) {
+	if r < utf8.RuneSelf {
+		if err := b.WriteByte(byte(r)); err != nil {
+			return 0, err
+		}
+		return 1, nil
+	}
+	var tmp [utf8.UTFMax]byte
+	n := utf8.EncodeRune(tmp[:], r)
+	return b.Write(tmp[:n])
+}
+
+// UnreadByte unreads the last byte returned by ReadByte or ReadRune, moving the read pointer back by one. It
+// returns ErrInvalidUnreadByte if the preceding call was not one of those two methods.
+func (b *Buffer) UnreadByte() error {
+	if b.lastByte < 0 {
+		return ErrInvalidUnreadByte
+	}
+	if b.r == 0 {
+		b.r = b.size - 1
+	} else {
+		b.r--
+	}
+	b.isFull = b.r == b.w
+	b.lastByte = -1
+	b.lastRuneSize = -1
+	return nil
+}
+
+// UnreadRune unreads the last rune returned by ReadRune, moving the read pointer back by that rune's size. It
+// returns ErrInvalidUnreadRune if the preceding call was not ReadRune, or if a subsequent UnreadByte already
+// consumed the bookkeeping.
+func (b *Buffer) UnreadRune() error {
+	if b.lastRuneSize < 0 {
+		return ErrInvalidUnreadRune
+	}
+	b.r -= b.lastRuneSize
+	for b.r < 0 {
+		b.r += b.size
+	}
+	b.isFull = b.r == b.w
+	b.lastByte = -1
+	b.lastRuneSize = -1
+	return nil
+}
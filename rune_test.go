@@ -0,0 +1,116 @@
+package ringbuffer
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestReadRuneWrapBoundary(t *testing.T) {
+	// size=4, positioned so the 3-byte encoding of '世' straddles the end of buf.
+	b := New(WithSize(4))
+	b.Write([]byte("ab"))
+	b.Discard(2)
+	b.r, b.w = 3, 3 // one free byte before the wrap, rest after it
+
+	want := "世"
+	if len(want) != 3 {
+		t.Fatalf("test setup: want a 3-byte rune")
+	}
+	copy(b.buf[3:4], want[0:1])
+	copy(b.buf[0:2], want[1:3])
+	b.w = 2
+	b.isFull = false
+
+	r, size, err := b.ReadRune()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r != '世' || size != 3 {
+		t.Fatalf("r=%q size=%d, want 世/3", r, size)
+	}
+}
+
+func TestReadRuneChunkedSource(t *testing.T) {
+	// A source that only ever hands back one byte per Read call must still let ReadRune assemble a full
+	// multi-byte rune rather than giving up after the first byte.
+	src := &byteAtATimeReader{data: []byte("世")}
+	b := New(WithSize(8), WithSource(src))
+
+	r, size, err := b.ReadRune()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r != '世' || size != 3 {
+		t.Fatalf("r=%q size=%d, want 世/3", r, size)
+	}
+}
+
+func TestReadRuneInvalidByte(t *testing.T) {
+	b := New(WithSize(4))
+	b.WriteByte(0xff) // not a valid UTF-8 lead byte
+	r, size, err := b.ReadRune()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r != utf8.RuneError || size != 1 {
+		t.Fatalf("r=%q size=%d, want RuneError/1", r, size)
+	}
+}
+
+func TestUnreadByteInvalidAfterOtherOps(t *testing.T) {
+	b := New(WithSize(4))
+	b.Write([]byte("ab"))
+	b.ReadByte()
+	b.ReadByte() // valid UnreadByte target now
+
+	b.Write([]byte("c")) // any other mutating call invalidates the unread bookkeeping
+	if err := b.UnreadByte(); err != ErrInvalidUnreadByte {
+		t.Fatalf("err = %v, want ErrInvalidUnreadByte", err)
+	}
+}
+
+func TestUnreadRuneInvalidAfterUnreadByte(t *testing.T) {
+	b := New(WithSize(4))
+	b.WriteRune('世')
+	b.ReadRune()
+	b.UnreadByte() // consumes the unread bookkeeping, rewinding only one byte, not the whole rune
+	if err := b.UnreadRune(); err != ErrInvalidUnreadRune {
+		t.Fatalf("err = %v, want ErrInvalidUnreadRune", err)
+	}
+}
+
+func TestWriteRuneThenReadRuneRoundTrip(t *testing.T) {
+	b := New(WithSize(16))
+	for _, r := range "a界€" {
+		if _, err := b.WriteRune(r); err != nil {
+			t.Fatalf("WriteRune(%q): %v", r, err)
+		}
+	}
+
+	var got []rune
+	for {
+		r, _, err := b.ReadRune()
+		if err != nil {
+			break
+		}
+		got = append(got, r)
+	}
+	if string(got) != "a界€" {
+		t.Fatalf("got %q, want %q", string(got), "a界€")
+	}
+}
+
+// byteAtATimeReader hands back at most one byte per Read call, to exercise code paths that must tolerate a
+// source delivering a multi-byte sequence piecemeal.
+type byteAtATimeReader struct {
+	data []byte
+}
+
+func (r *byteAtATimeReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, nil
+	}
+	p[0] = r.data[0]
+	r.data = r.data[1:]
+	return 1, nil
+}
@@ -0,0 +1,156 @@
+package ringbuffer
+
+import (
+	"bufio"
+	"io"
+	"unsafe"
+)
+
+// ReadString reads until the first occurrence of delim, returning a string containing the data up to and
+// including the delimiter. If an attached source is exhausted before delim turns up, ReadString returns the
+// data read so far and io.EOF, exactly like ReadBytes. Unlike ReadBytes, the contiguous (non-wrapped) case
+// converts straight from buf to a string in a single allocation instead of first copying into an intermediate
+// []byte.
+func (b *Buffer) ReadString(delim byte) (s string, err error) {
+	for {
+		if i := b.IndexByte(delim); i >= 0 {
+			n := i + 1
+			if b.r+n <= b.size {
+				s = string(b.buf[b.r : b.r+n])
+			} else {
+				raw := b.byteRange(b.r, (b.r+n)%b.size)
+				s = unsafe.String(&raw[0], len(raw))
+			}
+			b.Discard(n)
+			return s, nil
+		}
+		if b.source == nil {
+			break
+		}
+		filled, ferr := b.Fill()
+		if ferr != nil {
+			err = ferr
+			break
+		}
+		if filled == 0 {
+			break
+		}
+	}
+
+	raw := b.Bytes()
+	if len(raw) == 0 {
+		if err == nil {
+			err = io.EOF
+		}
+		return "", err
+	}
+	b.Discard(len(raw))
+	if err == nil {
+		err = io.EOF
+	}
+	return unsafe.String(&raw[0], len(raw)), err
+}
+
+// ReadLine reads a single line, not including the end-of-line bytes, and strips a trailing \r\n or \n. If the
+// line is longer than the buffer's capacity, ReadLine returns isPrefix=true along with however much of the
+// line fit; the caller must call ReadLine again to read the rest, exactly as with bufio.Reader.ReadLine. The
+// returned line aliases the underlying buf where possible and is only valid until the next mutating call.
+func (b *Buffer) ReadLine() (line []byte, isPrefix bool, err error) {
+	for {
+		if i := b.IndexByte('\n'); i >= 0 {
+			n := i + 1
+			raw := b.peekContiguous(n)
+			b.Discard(n)
+			return trimCR(raw[:n-1]), false, nil
+		}
+		if b.IsFull() {
+			return b.drainContiguous(), true, nil
+		}
+		if b.source == nil {
+			break
+		}
+		filled, ferr := b.Fill()
+		if ferr != nil {
+			err = ferr
+			break
+		}
+		if filled == 0 {
+			break
+		}
+	}
+
+	if b.Length() == 0 {
+		if err == nil {
+			err = io.EOF
+		}
+		return nil, false, err
+	}
+	return trimCR(b.drainContiguous()), false, err
+}
+
+// peekContiguous returns the next n buffered bytes without advancing the read pointer, aliasing buf directly
+// when they do not wrap and falling back to a copy via byteRange when they do.
+func (b *Buffer) peekContiguous(n int) []byte {
+	if b.r+n <= b.size {
+		return b.buf[b.r : b.r+n]
+	}
+	return b.byteRange(b.r, (b.r+n)%b.size)
+}
+
+// drainContiguous discards all currently buffered bytes and returns them, aliasing buf directly when they do
+// not wrap and copying via byteRange when they do.
+func (b *Buffer) drainContiguous() []byte {
+	bLen := b.Length()
+	raw := b.peekContiguous(bLen)
+	b.Discard(bLen)
+	return raw
+}
+
+func trimCR(p []byte) []byte {
+	if n := len(p); n > 0 && p[n-1] == '\r' {
+		return p[:n-1]
+	}
+	return p
+}
+
+// Scan runs split over the buffered bytes the same way bufio.Scanner does, returning the next token. Unlike
+// bufio.Scanner, which copies into its own growable buffer, Scan hands split the ring's own bytes directly
+// when they are contiguous, only copying when the data wraps; split must not retain or modify the slice it is
+// given beyond the call. When split needs more input than is currently buffered, Scan pulls from an attached
+// source via Fill; with no source, or once the source is exhausted, it gives split a final atEOF=true call
+// before returning io.EOF.
+func (b *Buffer) Scan(split bufio.SplitFunc) (token []byte, err error) {
+	atEOF := b.source == nil
+	for {
+		data, tail, _ := b.Peek(b.Length())
+		if tail != nil {
+			data = b.Bytes()
+		}
+
+		advance, tok, serr := split(data, atEOF)
+		if serr != nil {
+			if serr == bufio.ErrFinalToken {
+				b.Discard(advance)
+				return tok, nil
+			}
+			return nil, serr
+		}
+		if advance > 0 {
+			b.Discard(advance)
+		}
+		if tok != nil {
+			return tok, nil
+		}
+		if atEOF {
+			return nil, io.EOF
+		}
+
+		filled, ferr := b.Fill()
+		if ferr != nil && ferr != io.EOF {
+			return nil, ferr
+		}
+		if filled == 0 {
+			atEOF = true
+		}
+	}
+}
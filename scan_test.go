@@ -0,0 +1,105 @@
+package ringbuffer
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+	"testing/iotest"
+)
+
+func TestReadStringWrapAllocation(t *testing.T) {
+	// size=4, r=3, w=3, isFull: buffered bytes "ab\nc" wrap from the end of buf around to the front, so the
+	// delimiter falls in the wrapped region and ReadString must take its copying path, not the contiguous one.
+	b := New(WithSize(4))
+	b.buf[0], b.buf[1], b.buf[2], b.buf[3] = 'b', '\n', 'c', 'a'
+	b.r, b.w, b.isFull = 3, 3, true
+
+	s, err := b.ReadString('\n')
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s != "ab\n" {
+		t.Fatalf("s = %q, want %q", s, "ab\n")
+	}
+	if !b.Equal([]byte("c")) {
+		t.Fatalf("buffer = %q, want %q", b.Bytes(), "c")
+	}
+}
+
+func TestReadLineFullBufferIsPrefixContiguous(t *testing.T) {
+	b := New(WithSize(4))
+	b.Write([]byte("abcd")) // fills the buffer with no '\n' and no source to pull more from
+
+	line, isPrefix, err := b.ReadLine()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !isPrefix {
+		t.Fatalf("isPrefix = false, want true: the line exceeds the buffer's capacity")
+	}
+	if string(line) != "abcd" {
+		t.Fatalf("line = %q, want %q", line, "abcd")
+	}
+}
+
+func TestReadLineFullBufferIsPrefixWrapped(t *testing.T) {
+	// size=4, r=2, w=2, isFull: buffered bytes "cdab" wrap from the end of buf around to the front.
+	b := New(WithSize(4))
+	b.buf[0], b.buf[1], b.buf[2], b.buf[3] = 'a', 'b', 'c', 'd'
+	b.r, b.w, b.isFull = 2, 2, true
+
+	line, isPrefix, err := b.ReadLine()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !isPrefix {
+		t.Fatalf("isPrefix = false, want true")
+	}
+	if string(line) != "cdab" {
+		t.Fatalf("line = %q, want %q", line, "cdab")
+	}
+	if b.Length() != 0 {
+		t.Fatalf("ReadLine must drain the buffered bytes it returns, Length() = %d", b.Length())
+	}
+}
+
+func TestScanSplitsWrappedBuffer(t *testing.T) {
+	// size=8, r=6, w=6, isFull: buffered bytes "foo\nbar\n" wrap from the end of buf around to the front, so
+	// Scan's Peek(Length()) returns a non-nil tail and must fall back to b.Bytes() to hand split a linear slice.
+	b := New(WithSize(8))
+	b.buf[0], b.buf[1], b.buf[2], b.buf[3] = 'o', '\n', 'b', 'a'
+	b.buf[4], b.buf[5], b.buf[6], b.buf[7] = 'r', '\n', 'f', 'o'
+	b.r, b.w, b.isFull = 6, 6, true
+
+	tok, err := b.Scan(bufio.ScanLines)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(tok) != "foo" {
+		t.Fatalf("tok = %q, want %q", tok, "foo")
+	}
+
+	tok, err = b.Scan(bufio.ScanLines)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(tok) != "bar" {
+		t.Fatalf("tok = %q, want %q", tok, "bar")
+	}
+}
+
+func TestScanPullsFromSourceOnExhaustion(t *testing.T) {
+	b := New(WithSize(4), WithSource(iotest.OneByteReader(bytes.NewReader([]byte("a b c")))))
+
+	var got []string
+	for {
+		tok, err := b.Scan(bufio.ScanWords)
+		if err != nil {
+			break
+		}
+		got = append(got, string(tok))
+	}
+	if len(got) != 3 || got[0] != "a" || got[1] != "b" || got[2] != "c" {
+		t.Fatalf("got %v, want [a b c]", got)
+	}
+}
@@ -0,0 +1,92 @@
+package ringbuffer
+
+import "io"
+
+// maxConsecutiveEmptyReads bounds the number of consecutive zero-byte, nil-error reads Fill tolerates from the
+// attached source before giving up, mirroring the safeguard bufio.Reader applies against buggy Readers.
+const maxConsecutiveEmptyReads = 100
+
+// WithSource attaches r as the buffer's upstream reader. Once attached, Read and ReadByte pull from r into the
+// free region of the ring whenever the ring is empty, so Buffer can be used as a fixed-size buffered reader
+// directly, without layering a bufio.Reader on top of it.
+func WithSource(r io.Reader) Option {
+	return func(b *Buffer) {
+		b.source = r
+	}
+}
+
+// WithSink attaches w as the buffer's downstream writer. Once attached, Write flushes the ring to w whenever
+// it is full, so Buffer can be used as a fixed-size buffered writer directly.
+func WithSink(w io.Writer) Option {
+	return func(b *Buffer) {
+		b.sink = w
+	}
+}
+
+// Fill reads from the attached source into the free region of the ring and returns the number of bytes read.
+// It is a no-op returning (0, nil) if no source is attached, and returns ErrIsFull if the ring has no free
+// space. When the free region wraps around the end of buf, Fill tops it up in two passes: one for the segment
+// running up to the end of buf, and, if that segment filled completely, one more for the segment starting back
+// at the front. Both passes go through readFill, so a source that repeatedly returns (0, nil) in either
+// segment is retried up to maxConsecutiveEmptyReads times before Fill gives up with io.ErrNoProgress, matching
+// bufio.Reader.
+func (b *Buffer) Fill() (n int, err error) {
+	if b.source == nil {
+		return 0, nil
+	}
+	if b.isFull {
+		return 0, ErrIsFull
+	}
+
+	var c1 int
+	if b.w >= b.r {
+		c1 = b.size - b.w
+	} else {
+		c1 = b.r - b.w
+	}
+
+	n, err = b.readFill(b.buf[b.w : b.w+c1])
+	b.w = (b.w + n) % b.size
+
+	if err == nil && n == c1 && b.w < b.r {
+		var n2 int
+		n2, err = b.readFill(b.buf[b.w:b.r])
+		n += n2
+		b.w = (b.w + n2) % b.size
+	}
+
+	if n > 0 && b.w == b.r {
+		b.isFull = true
+	}
+	if n > 0 {
+		b.invalidateUnread()
+	}
+	if err == io.EOF {
+		err = nil
+	}
+	return n, err
+}
+
+// readFill reads into p, retrying while source returns (0, nil) up to maxConsecutiveEmptyReads times before
+// giving up with io.ErrNoProgress.
+func (b *Buffer) readFill(p []byte) (n int, err error) {
+	for empty := 0; ; empty++ {
+		n, err = b.source.Read(p)
+		if n != 0 || err != nil {
+			return n, err
+		}
+		if empty+1 >= maxConsecutiveEmptyReads {
+			return 0, io.ErrNoProgress
+		}
+	}
+}
+
+// flush writes all currently buffered bytes to the attached sink, mirroring bufio.Writer.Flush. Write calls it
+// automatically when the ring is full and a sink is attached.
+func (b *Buffer) flush() (int, error) {
+	if b.sink == nil {
+		return 0, nil
+	}
+	n, err := b.WriteTo(b.sink)
+	return int(n), err
+}
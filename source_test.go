@@ -0,0 +1,101 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+// stallAfterReader returns fill on its first Read call and (0, nil) on every call after that, modeling a
+// source that stops making progress without signaling an error or EOF.
+type stallAfterReader struct {
+	fill  []byte
+	calls int
+}
+
+func (r *stallAfterReader) Read(p []byte) (int, error) {
+	r.calls++
+	if r.calls == 1 {
+		return copy(p, r.fill), nil
+	}
+	return 0, nil
+}
+
+func TestFillWraps(t *testing.T) {
+	// size=8, r=2, w=6: the free region is buf[6:8] followed by buf[0:2], i.e. it wraps.
+	b := New(WithSize(8))
+	b.Write(bytes.Repeat([]byte{'x'}, 8))
+	b.Discard(2)
+	b.r, b.w = 2, 6
+
+	b.source = bytes.NewBufferString("ABCD")
+
+	n, err := b.Fill()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 4 {
+		t.Fatalf("n = %d, want 4", n)
+	}
+	if !b.IsFull() {
+		t.Fatalf("expected buffer to be full after filling both wrap segments")
+	}
+	if string(b.buf[6:8]) != "AB" {
+		t.Fatalf("segment 1 (buf[6:8]) = %q, want \"AB\"", b.buf[6:8])
+	}
+	if string(b.buf[0:2]) != "CD" {
+		t.Fatalf("segment 2 (buf[0:2]) = %q, want \"CD\"", b.buf[0:2])
+	}
+}
+
+func TestFillWrapSegmentRetriesOnZeroByteReads(t *testing.T) {
+	// Segment 1 (up to the end of buf) fills in one read; segment 2 (wrapped, at the front) then stalls at
+	// (0, nil) forever, which must surface io.ErrNoProgress instead of being silently dropped.
+	b := New(WithSize(8))
+	b.Write(bytes.Repeat([]byte{'x'}, 8))
+	b.Discard(2)
+	b.r, b.w = 2, 6
+
+	b.source = &stallAfterReader{fill: []byte{'A', 'B'}}
+
+	n, err := b.Fill()
+	if !errors.Is(err, io.ErrNoProgress) {
+		t.Fatalf("err = %v, want io.ErrNoProgress", err)
+	}
+	if n != 2 {
+		t.Fatalf("n = %d, want 2 (segment 1 bytes only)", n)
+	}
+}
+
+func TestReadPullsFromSource(t *testing.T) {
+	b := New(WithSize(4), WithSource(bytes.NewBufferString("hello world")))
+	out := make([]byte, 20)
+	n, err := b.Read(out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(out[:n]) != "hell" {
+		t.Fatalf("got %q, want %q", out[:n], "hell")
+	}
+}
+
+func TestWriteFlushesToSink(t *testing.T) {
+	var sink bytes.Buffer
+	b := New(WithSize(4), WithSink(&sink))
+	if _, err := b.Write([]byte("ab")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Write([]byte("cd")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Write([]byte("ef")); err != nil {
+		t.Fatal(err)
+	}
+	if sink.String() != "abcd" {
+		t.Fatalf("sink = %q, want %q", sink.String(), "abcd")
+	}
+	if !b.Equal([]byte("ef")) {
+		t.Fatalf("buffer = %q, want %q", b.Bytes(), "ef")
+	}
+}